@@ -5,8 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/fatih/color"
@@ -28,10 +26,27 @@ var (
 type Point [2]int
 type Color []Point
 
+// Renderable is implemented by any puzzle type - Board, NumberGrid - that
+// a generic front-end (tui.RenderStatic) can draw without knowing its rules.
+type Renderable interface {
+	Lines() int
+	Cols() int
+	GridString() string
+}
+
 type Board struct {
 	grid  [][]int
 	flows []Color
 	sync.RWMutex
+
+	invalidators []func()
+
+	// Name, Author and Difficulty are free-form puzzle metadata. They
+	// round-trip through JSONFormat and CompactFormat but otherwise
+	// don't affect play.
+	Name       string
+	Author     string
+	Difficulty string
 }
 
 func colorwrapper(c *color.Color) func(string, ...interface{}) string {
@@ -40,98 +55,16 @@ func colorwrapper(c *color.Color) func(string, ...interface{}) string {
 	}
 }
 
+// New reads a puzzle from txt, auto-detecting which Format it's encoded in
+// (see Sniff) and decoding it with that Format.
 func New(txt io.ReadCloser) (*Board, error) {
-	board := &Board{}
-
 	r := bufio.NewReader(txt)
-
-	sizeString, err := r.ReadString('\n')
-	if err != nil {
-		err = fmt.Errorf("error reading input %s", err)
-		return board, err
-	}
-	lines, cols, err := getSize(sizeString)
+	format, err := Sniff(r)
 	if err != nil {
-		return board, err
-	}
-
-	fmt.Printf("board of %d lines and %d cols\n", lines, cols)
-	board.grid = make([][]int, lines)
-	for i := 0; i < cols; i++ {
-		board.grid[i] = make([]int, cols)
-	}
-
-	index := 0
-	for line := ""; ; line, err = r.ReadString('\n') {
-		readErr := insertPoints(board, line, index)
-		if err != nil || readErr != nil {
-			err = readErr
-			break
-		}
-		index++
-	}
-	if err != io.EOF && err != nil {
-		fmt.Println("Error reading file:", err)
-	}
-
-	return board, nil
-}
-
-func getSize(s string) (int, int, error) {
-	badFormatErr := fmt.Errorf("Bad format, first line should indicate the size of the board (e.g. '5,5')")
-	split := strings.Split(strings.Trim(s, "\n"), ",")
-	if len(split) != 2 {
-		fmt.Println(split)
-		return 0, 0, badFormatErr
-	}
-
-	lines, err := strconv.Atoi(split[0])
-	cols, err2 := strconv.Atoi(split[1])
-	if err != nil || err2 != nil {
-		fmt.Println(err, err2)
-		return 0, 0, badFormatErr
-	}
-
-	return lines, cols, nil
-
-}
-
-// not threadsafe
-func insertPoints(board *Board, line string, index int) error {
-	if line == "" {
-		return nil
-	}
-
-	badFormatErr := fmt.Errorf("Bad format, lines should indicate the positions of 2 points (e.g. '0,0 0,3')")
-	points := strings.Split(strings.Trim(line, "\n"), " ")
-	if len(points) != 2 {
-		fmt.Println(points)
-		return badFormatErr
+		return &Board{}, err
 	}
 
-	c := Color{}
-	for _, point := range points {
-		coords := strings.Split(point, ",")
-		if len(coords) != 2 {
-			fmt.Println(coords)
-			return badFormatErr
-		}
-
-		i, err := strconv.Atoi(coords[0])
-		j, err2 := strconv.Atoi(coords[1])
-
-		// Check points are valid coordinates whithin specified board size
-		if err != nil || err2 != nil || i < 0 || i > len(board.grid) || j < 0 || j > len(board.grid[0]) {
-			fmt.Println(err, err2, i, j)
-			return badFormatErr
-		}
-		board.grid[i][j] = index
-		p := Point{i, j}
-		c = append(c, p)
-
-	}
-	board.flows = append(board.flows, c)
-	return nil
+	return format.Decode(r)
 }
 
 // threadsafe
@@ -139,7 +72,11 @@ func (b *Board) Clone() *Board {
 	//b.RLock()
 	//defer b.RUnlock()
 
-	newBoard := &Board{}
+	newBoard := &Board{
+		Name:       b.Name,
+		Author:     b.Author,
+		Difficulty: b.Difficulty,
+	}
 
 	lines := b.Lines()
 	cols := b.Cols()
@@ -156,7 +93,11 @@ func (b *Board) Clone() *Board {
 		}
 	}
 	for _, flow := range b.flows {
-		newBoard.flows = append(newBoard.flows, flow)
+		// Deep-copy the Point slice: sharing it with b would let a
+		// mutation on one clone's flow (Retract shifts points within
+		// the existing backing array) be visible on the other,
+		// corrupting concurrent backtracking snapshots.
+		newBoard.flows = append(newBoard.flows, append(Color{}, flow...))
 	}
 
 	return newBoard
@@ -165,18 +106,21 @@ func (b *Board) Clone() *Board {
 // threadSafe
 func (b *Board) ColorCell(colorIndex, line, col int) error {
 	b.Lock()
-	defer b.Unlock()
 	if colorIndex < 0 || colorIndex >= len(b.flows) {
+		b.Unlock()
 		return errors.New("color index out of range")
 	}
 	if line < 0 || line >= b.Lines() {
+		b.Unlock()
 		return errors.New("X out of range")
 	}
 	if col < 0 || col >= b.Cols() {
+		b.Unlock()
 		return errors.New("Y out of range")
 	}
 
 	if b.grid[line][col] != 0 {
+		b.Unlock()
 		return errors.New("Cell already occupied")
 	}
 
@@ -187,14 +131,69 @@ func (b *Board) ColorCell(colorIndex, line, col int) error {
 	}
 	updatedC := append(c[:len(c)-1], Point{line, col}, c[len(c)-1])
 	if !AreAllAjacent(updatedC[:len(c)]) {
+		b.Unlock()
 		return fmt.Errorf("Cells are not ajacent: %v", updatedC[:len(c)])
 	}
 	b.grid[line][col] = colorIndex + 1
 	b.flows[colorIndex] = updatedC
+	b.Unlock()
+
+	b.Invalidate()
+
+	return nil
+}
+
+// threadSafe
+//
+// Retract undoes the most recent ColorCell call for colorIndex, clearing
+// its tip cell back to empty. It's a no-op error, not a panic, once the
+// flow is back down to just its two original endpoints - there's nothing
+// further to retract.
+func (b *Board) Retract(colorIndex int) error {
+	b.Lock()
+	if colorIndex < 0 || colorIndex >= len(b.flows) {
+		b.Unlock()
+		return errors.New("color index out of range")
+	}
+
+	c := b.flows[colorIndex]
+	if len(c) <= 2 {
+		b.Unlock()
+		return errors.New("flow has no extension to retract")
+	}
+
+	tipIndex := len(c) - 2
+	tip := c[tipIndex]
+	b.grid[tip[0]][tip[1]] = 0
+	b.flows[colorIndex] = append(c[:tipIndex], c[tipIndex+1:]...)
+	b.Unlock()
+
+	b.Invalidate()
 
 	return nil
 }
 
+// OnInvalidate registers fn to be called every time the board's contents
+// change. Used by tui to know when to redraw without polling.
+func (b *Board) OnInvalidate(fn func()) {
+	b.Lock()
+	defer b.Unlock()
+	b.invalidators = append(b.invalidators, fn)
+}
+
+// Invalidate notifies all subscribers registered via OnInvalidate.
+// Thread safe
+func (b *Board) Invalidate() {
+	b.RLock()
+	fns := make([]func(), len(b.invalidators))
+	copy(fns, b.invalidators)
+	b.RUnlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
 func (b *Board) Solved() bool {
 	//b.RLock()
 	//defer b.RUnlock()
@@ -300,6 +299,28 @@ func (b *Board) ColorsString() string {
 	return s
 }
 
+// Flows returns a copy of each color's current path, endpoint first and
+// endpoint last with whatever cells have been filled in between. Mutating
+// the returned slices does not affect the board.
+// Thread safe
+func (b *Board) Flows() []Color {
+	b.RLock()
+	defer b.RUnlock()
+	flows := make([]Color, len(b.flows))
+	for i, c := range b.flows {
+		flows[i] = append(Color{}, c...)
+	}
+	return flows
+}
+
+// NumColors returns the number of flows on the board.
+// Thread safe
+func (b *Board) NumColors() int {
+	b.RLock()
+	defer b.RUnlock()
+	return len(b.flows)
+}
+
 // Thread safe
 func (b *Board) Get(line, col int) int {
 	b.RLock()
@@ -0,0 +1,134 @@
+package board
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// compactFormat packs the same information as JSONFormat - size, flows,
+// metadata - onto a single line, e.g.
+// "PF1:5x5:0,0-0,3;1,0-4,4:My Puzzle|me|easy". The size and flow parts use
+// only digits and "x,-;:"; the metadata is written verbatim with "|"
+// between its fields, and nothing on the line is percent-escaped.
+type compactFormat struct{}
+
+func (compactFormat) Decode(r io.Reader) (*Board, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return &Board{}, err
+	}
+	line := strings.TrimSpace(string(data))
+	badFormatErr := fmt.Errorf("board: bad compact format %q", line)
+
+	if !strings.HasPrefix(line, compactMagic) {
+		return &Board{}, badFormatErr
+	}
+	line = strings.TrimPrefix(line, compactMagic)
+
+	parts := strings.SplitN(line, ":", 3)
+	if len(parts) < 2 {
+		return &Board{}, badFormatErr
+	}
+
+	dims := strings.SplitN(parts[0], "x", 2)
+	if len(dims) != 2 {
+		return &Board{}, badFormatErr
+	}
+	lines, err := strconv.Atoi(dims[0])
+	cols, err2 := strconv.Atoi(dims[1])
+	if err != nil || err2 != nil {
+		return &Board{}, badFormatErr
+	}
+	if err := validateDimensions(lines, cols); err != nil {
+		return &Board{}, err
+	}
+
+	b := &Board{}
+	b.grid = make([][]int, lines)
+	for i := range b.grid {
+		b.grid[i] = make([]int, cols)
+	}
+
+	if parts[1] != "" {
+		for index, flowStr := range strings.Split(parts[1], ";") {
+			pointStrs := strings.Split(flowStr, "-")
+			if err := validateFlowLength(len(pointStrs)); err != nil {
+				return b, err
+			}
+			c := Color{}
+			for _, pointStr := range pointStrs {
+				coords := strings.SplitN(pointStr, ",", 2)
+				if len(coords) != 2 {
+					return b, badFormatErr
+				}
+				i, err := strconv.Atoi(coords[0])
+				j, err2 := strconv.Atoi(coords[1])
+				if err != nil || err2 != nil {
+					return b, badFormatErr
+				}
+				if err := validateCoord(lines, cols, i, j); err != nil {
+					return b, err
+				}
+				// +1 so an endpoint's marker is never confused with
+				// an empty cell (0), matching what ColorCell writes.
+				b.grid[i][j] = index + 1
+				c = append(c, Point{i, j})
+			}
+			b.flows = append(b.flows, c)
+		}
+	}
+
+	if len(parts) == 3 {
+		meta := strings.SplitN(parts[2], "|", 3)
+		if len(meta) > 0 {
+			b.Name = meta[0]
+		}
+		if len(meta) > 1 {
+			b.Author = meta[1]
+		}
+		if len(meta) > 2 {
+			b.Difficulty = meta[2]
+		}
+	}
+
+	return b, nil
+}
+
+func (compactFormat) Encode(w io.Writer, b *Board) error {
+	if err := validateCompactMetadata(b.Name, b.Author, b.Difficulty); err != nil {
+		return err
+	}
+
+	var flowStrs []string
+	for _, c := range b.Flows() {
+		var pointStrs []string
+		for _, p := range c {
+			pointStrs = append(pointStrs, fmt.Sprintf("%d,%d", p[0], p[1]))
+		}
+		flowStrs = append(flowStrs, strings.Join(pointStrs, "-"))
+	}
+
+	line := fmt.Sprintf("%s%dx%d:%s", compactMagic, b.Lines(), b.Cols(), strings.Join(flowStrs, ";"))
+	if b.Name != "" || b.Author != "" || b.Difficulty != "" {
+		line += fmt.Sprintf(":%s|%s|%s", b.Name, b.Author, b.Difficulty)
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// validateCompactMetadata rejects metadata that would corrupt the compact
+// line's own delimiters: Decode splits Name/Author/Difficulty on "|", so
+// any of the three containing one would silently shift into the next
+// field on round trip (or, for a newline, spill into a second line the
+// format has no way to read back). JSONFormat has no such restriction,
+// so puzzles with metadata like this can still round-trip there.
+func validateCompactMetadata(name, author, difficulty string) error {
+	for _, field := range []string{name, author, difficulty} {
+		if strings.ContainsAny(field, "|\n") {
+			return fmt.Errorf("board: compact format metadata cannot contain '|' or a newline, got %q", field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package board
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// jsonFormat captures grid size, endpoints, any cells a flow has already
+// been extended into, and free-form metadata, all as JSON.
+type jsonFormat struct{}
+
+type jsonBoard struct {
+	Lines      int       `json:"lines"`
+	Cols       int       `json:"cols"`
+	Flows      [][]Point `json:"flows"`
+	Name       string    `json:"name,omitempty"`
+	Author     string    `json:"author,omitempty"`
+	Difficulty string    `json:"difficulty,omitempty"`
+}
+
+func (jsonFormat) Decode(r io.Reader) (*Board, error) {
+	var jb jsonBoard
+	if err := json.NewDecoder(r).Decode(&jb); err != nil {
+		return &Board{}, fmt.Errorf("board: invalid json format: %v", err)
+	}
+
+	b := &Board{
+		Name:       jb.Name,
+		Author:     jb.Author,
+		Difficulty: jb.Difficulty,
+	}
+	if err := validateDimensions(jb.Lines, jb.Cols); err != nil {
+		return b, err
+	}
+	b.grid = make([][]int, jb.Lines)
+	for i := range b.grid {
+		b.grid[i] = make([]int, jb.Cols)
+	}
+
+	for index, points := range jb.Flows {
+		if err := validateFlowLength(len(points)); err != nil {
+			return b, err
+		}
+		c := Color{}
+		for _, p := range points {
+			if err := validateCoord(jb.Lines, jb.Cols, p[0], p[1]); err != nil {
+				return b, err
+			}
+			// +1 so an endpoint's marker is never confused with an
+			// empty cell (0), matching what ColorCell writes.
+			b.grid[p[0]][p[1]] = index + 1
+			c = append(c, p)
+		}
+		b.flows = append(b.flows, c)
+	}
+
+	return b, nil
+}
+
+func (jsonFormat) Encode(w io.Writer, b *Board) error {
+	jb := jsonBoard{
+		Lines:      b.Lines(),
+		Cols:       b.Cols(),
+		Flows:      make([][]Point, 0, b.NumColors()),
+		Name:       b.Name,
+		Author:     b.Author,
+		Difficulty: b.Difficulty,
+	}
+	for _, c := range b.Flows() {
+		jb.Flows = append(jb.Flows, []Point(c))
+	}
+	return json.NewEncoder(w).Encode(jb)
+}
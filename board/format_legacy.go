@@ -0,0 +1,118 @@
+package board
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// legacyFormat is the original "5,5\n0,0 0,3\n..." text format: a first
+// line giving the board size, then one line per flow giving its two
+// endpoints. Kept for back-compat; JSONFormat and CompactFormat are
+// preferred for anything new since they also capture partial flows and
+// metadata.
+type legacyFormat struct{}
+
+func (legacyFormat) Decode(r io.Reader) (*Board, error) {
+	br := bufio.NewReader(r)
+	b := &Board{}
+
+	sizeString, err := br.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return b, fmt.Errorf("board: error reading input: %v", err)
+	}
+	lines, cols, err := parseLegacySize(sizeString)
+	if err != nil {
+		return b, err
+	}
+	if err := validateDimensions(lines, cols); err != nil {
+		return b, err
+	}
+
+	b.grid = make([][]int, lines)
+	for i := 0; i < lines; i++ {
+		b.grid[i] = make([]int, cols)
+	}
+
+	index := 0
+	for {
+		line, readErr := br.ReadString('\n')
+		if strings.TrimSpace(line) != "" {
+			if err := parseLegacyFlow(b, line, index); err != nil {
+				return b, err
+			}
+			index++
+		}
+		if readErr != nil {
+			break
+		}
+	}
+
+	return b, nil
+}
+
+func (legacyFormat) Encode(w io.Writer, b *Board) error {
+	if _, err := fmt.Fprintf(w, "%d,%d\n", b.Lines(), b.Cols()); err != nil {
+		return err
+	}
+	for _, c := range b.Flows() {
+		if len(c) < 2 {
+			continue
+		}
+		start, end := c[0], c[len(c)-1]
+		if _, err := fmt.Fprintf(w, "%d,%d %d,%d\n", start[0], start[1], end[0], end[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseLegacySize(s string) (int, int, error) {
+	badFormatErr := fmt.Errorf("board: bad format, first line should indicate the size of the board (e.g. '5,5')")
+	split := strings.Split(strings.TrimSpace(s), ",")
+	if len(split) != 2 {
+		return 0, 0, badFormatErr
+	}
+
+	lines, err := strconv.Atoi(split[0])
+	cols, err2 := strconv.Atoi(split[1])
+	if err != nil || err2 != nil {
+		return 0, 0, badFormatErr
+	}
+
+	return lines, cols, nil
+}
+
+func parseLegacyFlow(b *Board, line string, index int) error {
+	badFormatErr := fmt.Errorf("board: bad format, lines should indicate the positions of 2 points (e.g. '0,0 0,3')")
+	points := strings.Fields(line)
+	if len(points) != 2 {
+		return badFormatErr
+	}
+
+	c := Color{}
+	for _, point := range points {
+		coords := strings.Split(point, ",")
+		if len(coords) != 2 {
+			return badFormatErr
+		}
+
+		i, err := strconv.Atoi(coords[0])
+		j, err2 := strconv.Atoi(coords[1])
+		if err != nil || err2 != nil {
+			return badFormatErr
+		}
+		if err := validateCoord(b.Lines(), b.Cols(), i, j); err != nil {
+			return err
+		}
+
+		// +1 so an endpoint's marker is never confused with an empty
+		// cell (0), matching the 1-indexed values ColorCell writes.
+		b.grid[i][j] = index + 1
+		c = append(c, Point{i, j})
+	}
+	b.flows = append(b.flows, c)
+	return nil
+}
@@ -0,0 +1,212 @@
+package board
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestValidateCoord(t *testing.T) {
+	cases := []struct {
+		i, j    int
+		wantErr bool
+	}{
+		{0, 0, false},
+		{4, 4, false},
+		{5, 0, true}, // i == lines, the original off-by-one
+		{0, 5, true}, // j == cols, the original off-by-one
+		{-1, 0, true},
+		{0, -1, true},
+	}
+	for _, c := range cases {
+		err := validateCoord(5, 5, c.i, c.j)
+		if (err != nil) != c.wantErr {
+			t.Errorf("validateCoord(5, 5, %d, %d) error = %v, wantErr %v", c.i, c.j, err, c.wantErr)
+		}
+	}
+}
+
+func TestValidateFlowLength(t *testing.T) {
+	cases := []struct {
+		points  int
+		wantErr bool
+	}{
+		{0, true},
+		{1, true},
+		{2, false},
+		{3, false},
+	}
+	for _, c := range cases {
+		err := validateFlowLength(c.points)
+		if (err != nil) != c.wantErr {
+			t.Errorf("validateFlowLength(%d) error = %v, wantErr %v", c.points, err, c.wantErr)
+		}
+	}
+}
+
+func TestValidateDimensions(t *testing.T) {
+	cases := []struct {
+		lines, cols int
+		wantErr     bool
+	}{
+		{5, 5, false},
+		{1, 1, false},
+		{0, 5, true},
+		{5, 0, true},
+		{-1, 5, true},
+		{5, -1, true},
+	}
+	for _, c := range cases {
+		err := validateDimensions(c.lines, c.cols)
+		if (err != nil) != c.wantErr {
+			t.Errorf("validateDimensions(%d, %d) error = %v, wantErr %v", c.lines, c.cols, err, c.wantErr)
+		}
+	}
+}
+
+func TestSniff(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		want  Format
+	}{
+		{"legacy", "5,5\n0,0 0,3\n", LegacyFormat},
+		{"json", `{"lines":5,"cols":5}`, JSONFormat},
+		{"compact", "PF1:5x5:0,0-0,3", CompactFormat},
+	}
+	for _, c := range cases {
+		r := bufio.NewReader(strings.NewReader(c.input))
+		got, err := Sniff(r)
+		if err != nil {
+			t.Fatalf("%s: Sniff returned error: %v", c.name, err)
+		}
+		if got != c.want {
+			t.Errorf("%s: Sniff returned %T, want %T", c.name, got, c.want)
+		}
+	}
+}
+
+func TestNewRejectsOutOfRangeLegacyPoint(t *testing.T) {
+	_, err := New(io.NopCloser(strings.NewReader("5,5\n0,0 5,0\n")))
+	if err == nil {
+		t.Fatal("expected an error for a point one past the grid's last row (i == lines)")
+	}
+}
+
+// TestDecodeRejectsNegativeDimensions is a regression test: all three
+// Decode implementations used to allocate the grid straight from a parsed
+// negative size, panicking on make([][]int, lines) instead of returning
+// a "bad format" error.
+func TestDecodeRejectsNegativeDimensions(t *testing.T) {
+	if _, err := New(io.NopCloser(strings.NewReader("-1,5\n"))); err == nil {
+		t.Error("LegacyFormat: expected an error for a negative line count")
+	}
+	if _, err := JSONFormat.Decode(strings.NewReader(`{"lines":-1,"cols":5,"flows":[]}`)); err == nil {
+		t.Error("JSONFormat: expected an error for a negative line count")
+	}
+	if _, err := CompactFormat.Decode(strings.NewReader("PF1:-1x5:")); err == nil {
+		t.Error("CompactFormat: expected an error for a negative line count")
+	}
+}
+
+func TestLegacyFormatRoundTrip(t *testing.T) {
+	b, err := New(io.NopCloser(strings.NewReader("5,5\n0,0 0,3\n1,0 4,4\n")))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := LegacyFormat.Encode(&buf, b); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	roundTripped, err := LegacyFormat.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if roundTripped.NumColors() != b.NumColors() {
+		t.Errorf("round trip produced %d flows, want %d", roundTripped.NumColors(), b.NumColors())
+	}
+}
+
+func TestJSONFormatRoundTrip(t *testing.T) {
+	b, err := New(io.NopCloser(strings.NewReader(`{"lines":5,"cols":5,"flows":[[[0,0],[0,3]],[[1,0],[4,4]]],"name":"n","author":"a","difficulty":"easy"}`)))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := JSONFormat.Encode(&buf, b); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	roundTripped, err := JSONFormat.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if roundTripped.NumColors() != b.NumColors() {
+		t.Errorf("round trip produced %d flows, want %d", roundTripped.NumColors(), b.NumColors())
+	}
+	if roundTripped.Name != b.Name || roundTripped.Author != b.Author || roundTripped.Difficulty != b.Difficulty {
+		t.Errorf("round trip metadata = %+v, want name/author/difficulty %q/%q/%q", roundTripped, b.Name, b.Author, b.Difficulty)
+	}
+	if roundTripped.Get(0, 0) == 0 {
+		t.Errorf("round trip lost the flow endpoint marker at (0,0)")
+	}
+}
+
+func TestJSONFormatRejectsShortFlow(t *testing.T) {
+	_, err := JSONFormat.Decode(strings.NewReader(`{"lines":3,"cols":3,"flows":[[[0,0]]]}`))
+	if err == nil {
+		t.Fatal("expected an error for a flow with a single point")
+	}
+}
+
+func TestCompactFormatRoundTrip(t *testing.T) {
+	b, err := New(io.NopCloser(strings.NewReader("PF1:5x5:0,0-0,3;1,0-4,4:n|a|easy")))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := CompactFormat.Encode(&buf, b); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+
+	roundTripped, err := CompactFormat.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if roundTripped.NumColors() != b.NumColors() {
+		t.Errorf("round trip produced %d flows, want %d", roundTripped.NumColors(), b.NumColors())
+	}
+	if roundTripped.Name != b.Name || roundTripped.Author != b.Author || roundTripped.Difficulty != b.Difficulty {
+		t.Errorf("round trip metadata = %+v, want name/author/difficulty %q/%q/%q", roundTripped, b.Name, b.Author, b.Difficulty)
+	}
+	if roundTripped.Get(0, 0) == 0 {
+		t.Errorf("round trip lost the flow endpoint marker at (0,0)")
+	}
+}
+
+func TestCompactFormatRejectsShortFlow(t *testing.T) {
+	_, err := CompactFormat.Decode(strings.NewReader("PF1:3x3:0,0"))
+	if err == nil {
+		t.Fatal("expected an error for a flow with a single point")
+	}
+}
+
+// TestCompactFormatEncodeRejectsPipeInMetadata is a regression test: Name,
+// Author and Difficulty are joined with "|" and split back out the same
+// way on Decode, so a field containing that character used to round-trip
+// into silently wrong metadata instead of erroring.
+func TestCompactFormatEncodeRejectsPipeInMetadata(t *testing.T) {
+	b := &Board{Author: "Smith|Jones"}
+	b.grid = [][]int{{0}}
+
+	var buf bytes.Buffer
+	if err := CompactFormat.Encode(&buf, b); err == nil {
+		t.Fatal("expected an error for an author containing '|'")
+	}
+}
@@ -0,0 +1,272 @@
+package board
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// NumberGrid is a second puzzle mode: every cell holds a value 1..N, and
+// 4-connected cells sharing a value form a Group. A move increments or
+// decrements an entire group by 1, possibly merging it with neighboring
+// groups that end up holding the same value. The goal is to reduce the
+// whole grid to a single group.
+type NumberGrid struct {
+	grid [][]int
+	sync.RWMutex
+}
+
+// Group is a maximal set of 4-connected cells sharing the same value.
+type Group struct {
+	Value int
+	Cells []Point
+}
+
+// Parse reads a whitespace-separated grid of integers, one row per line.
+func Parse(r io.Reader) (*NumberGrid, error) {
+	g := &NumberGrid{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		row := make([]int, len(fields))
+		for i, f := range fields {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("number grid: bad value %q: %v", f, err)
+			}
+			row[i] = v
+		}
+		if len(g.grid) > 0 && len(row) != len(g.grid[0]) {
+			return nil, fmt.Errorf("number grid: row %d has %d columns, want %d", len(g.grid), len(row), len(g.grid[0]))
+		}
+		g.grid = append(g.grid, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(g.grid) == 0 {
+		return nil, fmt.Errorf("number grid: empty input")
+	}
+	return g, nil
+}
+
+// assuming the layout of the grid never changes
+// (instead of implementing thread safety)
+func (g *NumberGrid) Lines() int { return len(g.grid) }
+
+// assuming the layout of the grid never changes
+// (instead of implementing thread safety)
+func (g *NumberGrid) Cols() int { return len(g.grid[0]) }
+
+// Thread safe
+func (g *NumberGrid) Get(line, col int) int {
+	g.RLock()
+	defer g.RUnlock()
+	return g.grid[line][col]
+}
+
+// Groups returns the grid's maximal 4-connected same-value regions.
+// Thread safe
+func (g *NumberGrid) Groups() []Group {
+	g.RLock()
+	defer g.RUnlock()
+	return groupsOf(g.grid)
+}
+
+// GridString renders the grid the same way Board.GridString does, so both
+// puzzle types can be drawn through the shared Renderable interface.
+// Thread safe
+func (g *NumberGrid) GridString() string {
+	g.RLock()
+	defer g.RUnlock()
+	s := "\n"
+	printdelimiter := func() {
+		for range g.grid[0] {
+			s += "+---"
+		}
+		s += "+\n"
+	}
+
+	printdelimiter()
+	for i := range g.grid {
+		for j := range g.grid[i] {
+			val := g.grid[i][j]
+			s += "|" + ColorFuncs[val%len(ColorFuncs)](" %d ", val)
+		}
+		s += "|\n"
+		printdelimiter()
+	}
+	return s
+}
+
+// MinMovesToSingleGroup finds the optimal number of moves needed to reduce
+// the whole grid to one group, searching breadth-first over grid states:
+// each move picks one group and tries +1 or -1 on its value, re-unioning it
+// with any neighbors that now share that value. Every move costs the same
+// (1), so plain BFS already returns the shortest path - no heuristic is
+// needed, and board sizes here are small enough that the state space stays
+// manageable.
+//
+// A* was tried first with numGroups-1 as the heuristic, but that bound
+// isn't admissible: a single move can merge a group with *every* one of
+// its neighbors at once, so the true distance can be far less than
+// numGroups-1 (e.g. a value bordering groups on three sides can erase all
+// three in one move), which made the search return suboptimal answers.
+func (g *NumberGrid) MinMovesToSingleGroup() int {
+	g.RLock()
+	start := cloneGrid(g.grid)
+	g.RUnlock()
+
+	if len(groupsOf(start)) <= 1 {
+		return 0
+	}
+
+	maxValue := 1
+	for _, row := range start {
+		for _, v := range row {
+			if v > maxValue {
+				maxValue = v
+			}
+		}
+	}
+
+	visited := map[string]bool{gridKey(start): true}
+	queue := []*gridState{newGridState(start, 0)}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, grp := range groupsOf(cur.grid) {
+			for _, delta := range [2]int{-1, 1} {
+				newValue := grp.Value + delta
+				if newValue < 1 || newValue > maxValue {
+					continue
+				}
+				next := cloneGrid(cur.grid)
+				for _, p := range grp.Cells {
+					next[p[0]][p[1]] = newValue
+				}
+				key := gridKey(next)
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+
+				nextCost := cur.cost + 1
+				if len(groupsOf(next)) <= 1 {
+					return nextCost
+				}
+				queue = append(queue, newGridState(next, nextCost))
+			}
+		}
+	}
+
+	// A single group is always reachable by repeatedly growing the
+	// grid's most common value, so this is unreachable in practice.
+	return -1
+}
+
+func cloneGrid(grid [][]int) [][]int {
+	clone := make([][]int, len(grid))
+	for i, row := range grid {
+		clone[i] = append([]int{}, row...)
+	}
+	return clone
+}
+
+// gridState is a node in the BFS queue: a grid snapshot and the number of
+// moves it took to reach it.
+type gridState struct {
+	grid [][]int
+	cost int
+}
+
+func newGridState(grid [][]int, cost int) *gridState {
+	return &gridState{grid: grid, cost: cost}
+}
+
+func gridKey(grid [][]int) string {
+	var sb strings.Builder
+	for _, row := range grid {
+		for _, v := range row {
+			fmt.Fprintf(&sb, "%d,", v)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// groupsOf computes the maximal 4-connected same-value regions of grid via
+// union-find.
+func groupsOf(grid [][]int) []Group {
+	lines, cols := len(grid), len(grid[0])
+	idx := func(i, j int) int { return i*cols + j }
+	uf := newUnionFind(lines * cols)
+	for i := 0; i < lines; i++ {
+		for j := 0; j < cols; j++ {
+			if i+1 < lines && grid[i+1][j] == grid[i][j] {
+				uf.union(idx(i, j), idx(i+1, j))
+			}
+			if j+1 < cols && grid[i][j+1] == grid[i][j] {
+				uf.union(idx(i, j), idx(i, j+1))
+			}
+		}
+	}
+
+	byRoot := map[int]*Group{}
+	var order []int
+	for i := 0; i < lines; i++ {
+		for j := 0; j < cols; j++ {
+			root := uf.find(idx(i, j))
+			grp, ok := byRoot[root]
+			if !ok {
+				grp = &Group{Value: grid[i][j]}
+				byRoot[root] = grp
+				order = append(order, root)
+			}
+			grp.Cells = append(grp.Cells, Point{i, j})
+		}
+	}
+
+	groups := make([]Group, len(order))
+	for i, root := range order {
+		groups[i] = *byRoot[root]
+	}
+	return groups
+}
+
+// unionFind is a standard disjoint-set with path halving.
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	uf := &unionFind{parent: make([]int, n)}
+	for i := range uf.parent {
+		uf.parent[i] = i
+	}
+	return uf
+}
+
+func (uf *unionFind) find(x int) int {
+	for uf.parent[x] != x {
+		uf.parent[x] = uf.parent[uf.parent[x]]
+		x = uf.parent[x]
+	}
+	return x
+}
+
+func (uf *unionFind) union(a, b int) {
+	ra, rb := uf.find(a), uf.find(b)
+	if ra != rb {
+		uf.parent[ra] = rb
+	}
+}
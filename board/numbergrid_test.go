@@ -0,0 +1,108 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	g, err := Parse(strings.NewReader("1 2\n3 3\n"))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if g.Lines() != 2 || g.Cols() != 2 {
+		t.Fatalf("got %dx%d grid, want 2x2", g.Lines(), g.Cols())
+	}
+	if g.Get(1, 0) != 3 || g.Get(1, 1) != 3 {
+		t.Errorf("Get(1, *) = %d, %d, want 3, 3", g.Get(1, 0), g.Get(1, 1))
+	}
+}
+
+// TestMinMovesToSingleGroupOvercountingHeuristic regression-tests a grid
+// where a single move merges a group with more than one neighbor at once,
+// so numGroups-1 (the original heuristic) overestimates the true distance.
+func TestMinMovesToSingleGroupOvercountingHeuristic(t *testing.T) {
+	g, err := Parse(strings.NewReader("1 3 2\n1 1 2\n1 3 2\n"))
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if got, want := g.MinMovesToSingleGroup(), 2; got != want {
+		t.Errorf("MinMovesToSingleGroup() = %d, want %d", got, want)
+	}
+}
+
+// TestMinMovesToSingleGroupMatchesBruteForce cross-checks
+// MinMovesToSingleGroup against an independent, unoptimized iterative
+// deepening search over a handful of small grids, to catch any future
+// regression to a suboptimal (or otherwise wrong) move count.
+func TestMinMovesToSingleGroupMatchesBruteForce(t *testing.T) {
+	grids := []string{
+		"1\n",
+		"1 1\n",
+		"1 2\n",
+		"1 2\n2 1\n",
+		"1 3 2\n1 1 2\n1 3 2\n",
+		"1 2 1\n2 2 2\n1 2 1\n",
+		"1 1 2\n3 3 2\n3 1 1\n",
+	}
+	for _, input := range grids {
+		g, err := Parse(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", input, err)
+		}
+		got := g.MinMovesToSingleGroup()
+		want := bruteForceMinMoves(cloneGrid(g.grid))
+		if got != want {
+			t.Errorf("grid %q: MinMovesToSingleGroup() = %d, want %d (brute force)", input, got, want)
+		}
+	}
+}
+
+// bruteForceMinMoves is a deliberately naive iterative-deepening search
+// used only to verify MinMovesToSingleGroup's answers in tests: it tries
+// every sequence of group moves, depth by depth, until one reduces the
+// grid to a single group.
+func bruteForceMinMoves(start [][]int) int {
+	maxValue := 1
+	for _, row := range start {
+		for _, v := range row {
+			if v > maxValue {
+				maxValue = v
+			}
+		}
+	}
+
+	if len(groupsOf(start)) <= 1 {
+		return 0
+	}
+
+	for depth := 1; depth <= 20; depth++ {
+		if bruteForceSearch(start, maxValue, depth) {
+			return depth
+		}
+	}
+	panic(fmt.Sprintf("bruteForceMinMoves: no solution found within 20 moves for %v", start))
+}
+
+func bruteForceSearch(grid [][]int, maxValue, depth int) bool {
+	if depth == 0 {
+		return len(groupsOf(grid)) <= 1
+	}
+	for _, grp := range groupsOf(grid) {
+		for _, delta := range [2]int{-1, 1} {
+			newValue := grp.Value + delta
+			if newValue < 1 || newValue > maxValue {
+				continue
+			}
+			next := cloneGrid(grid)
+			for _, p := range grp.Cells {
+				next[p[0]][p[1]] = newValue
+			}
+			if bruteForceSearch(next, maxValue, depth-1) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package solver
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/etticat/go-plumber-go/board"
+)
+
+// ParallelSolve is Solve spread across a pool of workers, each exploring
+// its own branch of the search tree and stealing work from peers once its
+// own is exhausted. The first worker to find a solution cancels the rest.
+func ParallelSolve(b *board.Board, workers int) (*board.Board, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deques := make([]*boardDeque, workers)
+	for i := range deques {
+		deques[i] = &boardDeque{}
+	}
+	deques[0].pushBottom(b.Clone())
+
+	var found int32
+	var idle int32
+	var result atomic.Value
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(id int) {
+			defer wg.Done()
+			parallelWorker(ctx, cancel, id, deques, &found, &idle, workers, &result)
+		}(i)
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&found) == 0 {
+		return nil, ErrNoSolution
+	}
+	return result.Load().(*board.Board), nil
+}
+
+// parallelWorker drains its own deque, stealing from a random peer's tail
+// once empty, until a solution is found, the context is cancelled, or
+// every worker is simultaneously idle (search exhausted with no solution).
+//
+// Termination can't be decided by inspecting queue contents: a worker that
+// just popped its last snapshot has emptied its deque but hasn't pushed
+// its children's snapshots yet, so every deque can read empty while that
+// worker is still about to produce more work. idle instead counts workers
+// that failed to find any work on their last attempt; a worker only
+// terminates once idle reaches workers, which requires every worker to be
+// simultaneously out of work - including the one busy processing the
+// board the others think doesn't exist.
+func parallelWorker(ctx context.Context, cancel context.CancelFunc, id int, deques []*boardDeque, found, idle *int32, workers int, result *atomic.Value) {
+	own := deques[id]
+	isIdle := false
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		cur, ok := own.popBottom()
+		if !ok {
+			cur, ok = stealFrom(deques, id)
+		}
+		if ok && isIdle {
+			atomic.AddInt32(idle, -1)
+			isIdle = false
+		}
+		if !ok {
+			if atomic.LoadInt32(found) != 0 || ctx.Err() != nil {
+				return
+			}
+			if !isIdle {
+				atomic.AddInt32(idle, 1)
+				isIdle = true
+			}
+			if atomic.LoadInt32(idle) >= int32(workers) {
+				return
+			}
+			runtime.Gosched()
+			continue
+		}
+
+		if atomic.LoadInt32(found) != 0 {
+			return
+		}
+
+		if cur.Solved() {
+			if atomic.CompareAndSwapInt32(found, 0, 1) {
+				result.Store(cur)
+				cancel()
+			}
+			return
+		}
+
+		choices := selectFlowsToExtend(cur)
+		if len(choices) == 0 {
+			continue // dead end or stranded cell, drop this branch
+		}
+		if !anyFlowCanReachPartner(cur) || hasUnreachableEmptyCell(cur) {
+			continue
+		}
+
+		for _, choice := range choices {
+			for _, move := range choice.moves {
+				next := cur.Clone()
+				if err := next.ColorCell(choice.flow, move[0], move[1]); err != nil {
+					continue
+				}
+				own.pushBottom(next)
+			}
+		}
+	}
+}
+
+// stealFrom tries to steal a board snapshot from the tail of a random
+// peer's deque, starting at a random offset so workers don't all contend
+// on the same victim.
+func stealFrom(deques []*boardDeque, self int) (*board.Board, bool) {
+	if len(deques) <= 1 {
+		return nil, false
+	}
+	start := rand.Intn(len(deques))
+	for i := 0; i < len(deques); i++ {
+		idx := (start + i) % len(deques)
+		if idx == self {
+			continue
+		}
+		if b, ok := deques[idx].stealTail(); ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// boardDeque is a work-stealing double-ended queue of board snapshots.
+// The owner pushes and pops its own bottom (LIFO, for DFS order); thieves
+// pop the tail instead, taking the oldest, least-explored branches so
+// stealing doesn't duplicate the owner's immediate next move.
+type boardDeque struct {
+	mu    sync.Mutex
+	items []*board.Board
+}
+
+func (d *boardDeque) pushBottom(b *board.Board) {
+	d.mu.Lock()
+	d.items = append(d.items, b)
+	d.mu.Unlock()
+}
+
+func (d *boardDeque) popBottom() (*board.Board, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := len(d.items)
+	if n == 0 {
+		return nil, false
+	}
+	b := d.items[n-1]
+	d.items = d.items[:n-1]
+	return b, true
+}
+
+func (d *boardDeque) stealTail() (*board.Board, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.items) == 0 {
+		return nil, false
+	}
+	b := d.items[0]
+	d.items = d.items[1:]
+	return b, true
+}
@@ -0,0 +1,78 @@
+package solver
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/etticat/go-plumber-go/board"
+)
+
+// stripedBoard10 is a 10x10 board with one flow per row, straight across
+// from column 0 to column 9. Unlike a single flow spanning the whole
+// grid, this is the shape a real Flow Free puzzle takes - many short,
+// well-constrained flows - so the benchmarks measure realistic search
+// performance instead of a single flow's worst-case Hamiltonian path.
+func stripedBoard10(tb testing.TB) *board.Board {
+	var sb strings.Builder
+	fmt.Fprintln(&sb, "10,10")
+	for row := 0; row < 10; row++ {
+		fmt.Fprintf(&sb, "%d,0 %d,9\n", row, row)
+	}
+
+	b, err := board.New(io.NopCloser(strings.NewReader(sb.String())))
+	if err != nil {
+		tb.Fatalf("failed to build benchmark board: %v", err)
+	}
+	return b
+}
+
+// backtrackyBoard6 is a 6x6 board whose two side flows each have a free
+// choice of route around the third, boxed-in flow in the middle. Both
+// routes satisfy every local prune (reachability, no stranded cells), so
+// the most-constrained-variable heuristic can't tell them apart up front
+// and the search has to commit to one, fill dozens of cells deep, and
+// backtrack out once it turns out to be the wrong one. stripedBoard10's
+// straight-across flows never force that: this is what exercises
+// ParallelSolve's work-stealing instead of letting one worker finish
+// the puzzle solo before its peers steal anything.
+func backtrackyBoard6(tb testing.TB) *board.Board {
+	b, err := board.New(io.NopCloser(strings.NewReader("6,6\n0,0 5,0\n0,5 5,5\n2,2 3,2\n")))
+	if err != nil {
+		tb.Fatalf("failed to build benchmark board: %v", err)
+	}
+	return b
+}
+
+func BenchmarkSolve(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := Solve(stripedBoard10(b)); err != nil {
+			b.Fatalf("Solve: %v", err)
+		}
+	}
+}
+
+func BenchmarkParallelSolve(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := ParallelSolve(stripedBoard10(b), 4); err != nil {
+			b.Fatalf("ParallelSolve: %v", err)
+		}
+	}
+}
+
+func BenchmarkSolveBacktracky(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := Solve(backtrackyBoard6(b)); err != nil {
+			b.Fatalf("Solve: %v", err)
+		}
+	}
+}
+
+func BenchmarkParallelSolveBacktracky(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := ParallelSolve(backtrackyBoard6(b), 4); err != nil {
+			b.Fatalf("ParallelSolve: %v", err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package solver
+
+import "testing"
+
+// TestParallelSolveMatchesSolve regression-tests ParallelSolve against the
+// same tied-flows board as TestSolveTiedFlowsCompeteForSameCell: every
+// worker shares selectFlowsToExtend with Solve, so it inherited the same
+// completeness bug and needs its own coverage now that it's fixed.
+func TestParallelSolveMatchesSolve(t *testing.T) {
+	b := newBoard(t, "4,2\n0,0 1,0\n2,0 3,0\n")
+
+	solved, err := ParallelSolve(b, 4)
+	if err != nil {
+		t.Fatalf("ParallelSolve returned error: %v", err)
+	}
+	if !solved.Solved() {
+		t.Fatalf("ParallelSolve returned an unsolved board:\n%s", solved.GridString())
+	}
+}
+
+func TestParallelSolveNoSolution(t *testing.T) {
+	b := newBoard(t, "2,2\n0,0 1,1\n0,1 1,0\n")
+
+	if _, err := ParallelSolve(b, 4); err != ErrNoSolution {
+		t.Fatalf("ParallelSolve returned %v, want ErrNoSolution", err)
+	}
+}
+
+// TestParallelSolveBacktrackyBoard regression-tests the idle-worker
+// termination fix: with the old allEmpty(deques) check, a worker could
+// observe every deque empty - including its own, the instant after it
+// popped a snapshot but before it pushed that snapshot's children - and
+// return early, leaving the rest of the search to a single worker. This
+// board has enough real backtracking (see backtrackyBoard6) to exercise
+// that race, unlike the other boards in this file which solve in a
+// handful of steps.
+func TestParallelSolveBacktrackyBoard(t *testing.T) {
+	b := backtrackyBoard6(t)
+
+	solved, err := ParallelSolve(b, 4)
+	if err != nil {
+		t.Fatalf("ParallelSolve returned error: %v", err)
+	}
+	if !solved.Solved() {
+		t.Fatalf("ParallelSolve returned an unsolved board:\n%s", solved.GridString())
+	}
+}
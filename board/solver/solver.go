@@ -0,0 +1,341 @@
+// Package solver completes a partially filled board.Board so every flow
+// connects its two endpoints and every cell is filled, per the usual Flow
+// Free rules.
+//
+// The search is a depth-first backtrack over board.Board snapshots
+// (board.Board.Clone), deepened iteratively so pathological puzzles fail
+// fast on a shallow limit before the next, deeper pass is tried. At each
+// step it branches over the flow(s) with the fewest legal continuations
+// (most-constrained-variable, with ties all tried rather than arbitrarily
+// broken) and prunes states that can no longer lead to a solution.
+package solver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/etticat/go-plumber-go/board"
+)
+
+// ErrNoSolution is returned when no completion exists for the given board.
+var ErrNoSolution = errors.New("solver: no solution")
+
+var neighbors = [4]board.Point{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+
+// Solve finds a completion of b where every flow connects its two
+// endpoints and every cell is filled. b itself is not mutated; the
+// returned board is a solved clone.
+func Solve(b *board.Board) (*board.Board, error) {
+	maxDepth := b.Lines() * b.Cols()
+	for depthLimit := initialDepthLimit(maxDepth); ; depthLimit *= 2 {
+		if solution := search(b.Clone(), depthLimit); solution != nil {
+			return solution, nil
+		}
+		if depthLimit >= maxDepth {
+			return nil, ErrNoSolution
+		}
+	}
+}
+
+func initialDepthLimit(maxDepth int) int {
+	if maxDepth < 8 {
+		return maxDepth
+	}
+	return maxDepth / 8
+}
+
+// SolveAll enumerates up to limit distinct solutions for b. A limit <= 0
+// means no limit.
+func SolveAll(b *board.Board, limit int) []*board.Board {
+	var solutions []*board.Board
+	searchAll(b.Clone(), func(solved *board.Board) bool {
+		solutions = append(solutions, solved)
+		return limit <= 0 || len(solutions) < limit
+	})
+	return solutions
+}
+
+// SolveStream runs the search in a goroutine, emitting every solved board
+// it finds on the returned channel. The intermediate boards the search
+// visits on the way are not emitted. The channel is closed once the search
+// finishes or ctx is cancelled.
+func SolveStream(ctx context.Context, b *board.Board) <-chan *board.Board {
+	out := make(chan *board.Board)
+	go func() {
+		defer close(out)
+		searchAll(b.Clone(), func(candidate *board.Board) bool {
+			select {
+			case out <- candidate:
+			case <-ctx.Done():
+				return false
+			}
+			return ctx.Err() == nil
+		})
+	}()
+	return out
+}
+
+// search performs a single depth-limited DFS pass and returns the first
+// solved board it finds, or nil if depthLimit is exhausted without one.
+func search(b *board.Board, depthLimit int) *board.Board {
+	var solution *board.Board
+	searchDepth(b, depthLimit, func(solved *board.Board) bool {
+		solution = solved
+		return false // stop after the first solution
+	})
+	return solution
+}
+
+// searchAll runs an unbounded DFS, calling onSolution for every solved
+// board found. onSolution returns whether to keep searching for more.
+func searchAll(b *board.Board, onSolution func(*board.Board) bool) {
+	searchDepth(b, b.Lines()*b.Cols(), onSolution)
+}
+
+// searchDepth is the shared recursive backtracking core. It returns
+// whether to keep going - false once onSolution has asked it to stop - so
+// the depth-limited and unlimited variants above can share it.
+func searchDepth(b *board.Board, depthLimit int, onSolution func(*board.Board) bool) bool {
+	if depthLimit < 0 {
+		return true
+	}
+	if b.Solved() {
+		return onSolution(b)
+	}
+
+	choices := selectFlowsToExtend(b)
+	if len(choices) == 0 {
+		// Either some incomplete flow is already stuck, or every flow
+		// is complete but cells remain that nothing can reach: either
+		// way this branch can't lead to a solution.
+		return true
+	}
+	if !anyFlowCanReachPartner(b) {
+		return true // an endpoint has been cut off from its partner
+	}
+	if hasUnreachableEmptyCell(b) {
+		return true // some empty cell no flow can ever pass through
+	}
+
+	for _, choice := range choices {
+		for _, move := range choice.moves {
+			candidate := b.Clone()
+			if err := candidate.ColorCell(choice.flow, move[0], move[1]); err != nil {
+				continue
+			}
+			if !searchDepth(candidate, depthLimit-1, onSolution) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// flowChoice is one flow the search could branch on next, paired with its
+// legal continuations.
+type flowChoice struct {
+	flow  int
+	moves []board.Point
+}
+
+// selectFlowsToExtend picks which flow(s) the search should branch on
+// next. An incomplete flow's tip being adjacent to its partner just means
+// it *could* stop there - Board.ColorCell happily keeps extending a flow
+// past that point, and a single-flow board needs exactly that to ever
+// fill every cell. So: prefer the most-constrained incomplete flows as
+// usual, but if every flow is already complete and the board still has
+// empty cells, fall back to extending the most-constrained complete ones.
+//
+// More than one flow can tie for most-constrained, and which of them
+// moves first isn't interchangeable the way plain CSP variable ordering
+// would suggest: two flows can be contending for the very same cell, and
+// committing to only one of them can walk the other into a dead end that
+// the same board was otherwise solvable from. So when tied flows want a
+// shared cell, every tied flow - not just the first one found - is
+// returned for searchDepth to try; otherwise only the first is (see
+// narrowIfDisjoint).
+//
+// The returned slice is empty if an incomplete flow is stuck (this branch
+// is a dead end) or if nothing can be extended at all.
+func selectFlowsToExtend(b *board.Board) []flowChoice {
+	for _, c := range b.Flows() {
+		if flowComplete(c) {
+			continue
+		}
+		if len(legalMoves(b, flowTip(c))) == 0 {
+			return nil
+		}
+	}
+
+	if choices := mostConstrained(b, false); len(choices) > 0 {
+		return narrowIfDisjoint(choices)
+	}
+	return narrowIfDisjoint(mostConstrained(b, true))
+}
+
+// narrowIfDisjoint drops a tie down to its first flow when that's safe: if
+// no two tied flows want the same cell, filling them in any order reaches
+// the same states, so trying only one avoids needless branching. If two or
+// more do want a shared cell, which one gets it first can be the
+// difference between a dead end and a solution (see selectFlowsToExtend),
+// so in that case every tied flow is kept.
+func narrowIfDisjoint(choices []flowChoice) []flowChoice {
+	if len(choices) < 2 {
+		return choices
+	}
+	seen := map[board.Point]bool{}
+	for _, c := range choices {
+		for _, m := range c.moves {
+			if seen[m] {
+				return choices
+			}
+			seen[m] = true
+		}
+	}
+	return choices[:1]
+}
+
+// mostConstrained returns every flow tied for fewest legal continuations
+// among those whose complete/incomplete state matches wantComplete, or
+// nil if none have any legal continuation.
+func mostConstrained(b *board.Board, wantComplete bool) []flowChoice {
+	var choices []flowChoice
+	best := -1
+	for i, c := range b.Flows() {
+		if flowComplete(c) != wantComplete {
+			continue
+		}
+		candidateMoves := legalMoves(b, flowTip(c))
+		if len(candidateMoves) == 0 {
+			continue
+		}
+		switch {
+		case best == -1 || len(candidateMoves) < best:
+			best = len(candidateMoves)
+			choices = []flowChoice{{i, candidateMoves}}
+		case len(candidateMoves) == best:
+			choices = append(choices, flowChoice{i, candidateMoves})
+		}
+	}
+	return choices
+}
+
+// flowTip returns the last filled cell of an in-progress flow: the first
+// endpoint if nothing has been extended yet, otherwise the most recently
+// placed cell.
+func flowTip(c board.Color) board.Point {
+	return c[len(c)-2]
+}
+
+// flowComplete reports whether the flow's tip already touches its other
+// endpoint, meaning it needs no further extension.
+func flowComplete(c board.Color) bool {
+	return board.AreAjacent(flowTip(c), c[len(c)-1])
+}
+
+// legalMoves returns the empty neighbor cells of p.
+func legalMoves(b *board.Board, p board.Point) []board.Point {
+	var moves []board.Point
+	for _, d := range neighbors {
+		n := board.Point{p[0] + d[0], p[1] + d[1]}
+		if n[0] < 0 || n[0] >= b.Lines() || n[1] < 0 || n[1] >= b.Cols() {
+			continue
+		}
+		if b.Get(n[0], n[1]) != 0 {
+			continue
+		}
+		moves = append(moves, n)
+	}
+	return moves
+}
+
+// anyFlowCanReachPartner reports whether every active flow's tip can still
+// reach its partner endpoint through empty cells, via BFS.
+func anyFlowCanReachPartner(b *board.Board) bool {
+	for _, c := range b.Flows() {
+		if flowComplete(c) {
+			continue
+		}
+		if !reachable(b, flowTip(c), c[len(c)-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// reachable is a BFS over empty cells (plus from and to themselves)
+// answering whether to can be reached from "from".
+func reachable(b *board.Board, from, to board.Point) bool {
+	visited := map[board.Point]bool{from: true}
+	queue := []board.Point{from}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p == to {
+			return true
+		}
+		for _, d := range neighbors {
+			n := board.Point{p[0] + d[0], p[1] + d[1]}
+			if n[0] < 0 || n[0] >= b.Lines() || n[1] < 0 || n[1] >= b.Cols() {
+				continue
+			}
+			if visited[n] {
+				continue
+			}
+			if n != to && b.Get(n[0], n[1]) != 0 {
+				continue
+			}
+			visited[n] = true
+			queue = append(queue, n)
+		}
+	}
+	return false
+}
+
+// hasUnreachableEmptyCell reports whether some empty cell cannot be
+// reached by any flow's tip, meaning it could never be filled - a
+// complete flow's tip still counts, since Board.ColorCell lets any flow
+// keep growing past the point where it first touches its partner.
+func hasUnreachableEmptyCell(b *board.Board) bool {
+	var tips []board.Point
+	for _, c := range b.Flows() {
+		tips = append(tips, flowTip(c))
+	}
+
+	reached := map[board.Point]bool{}
+	for _, t := range tips {
+		floodFill(b, t, reached)
+	}
+
+	for i := 0; i < b.Lines(); i++ {
+		for j := 0; j < b.Cols(); j++ {
+			if b.Get(i, j) == 0 && !reached[board.Point{i, j}] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func floodFill(b *board.Board, start board.Point, reached map[board.Point]bool) {
+	if reached[start] {
+		return
+	}
+	queue := []board.Point{start}
+	reached[start] = true
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range neighbors {
+			n := board.Point{p[0] + d[0], p[1] + d[1]}
+			if n[0] < 0 || n[0] >= b.Lines() || n[1] < 0 || n[1] >= b.Cols() {
+				continue
+			}
+			if reached[n] || b.Get(n[0], n[1]) != 0 {
+				continue
+			}
+			reached[n] = true
+			queue = append(queue, n)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package solver
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/etticat/go-plumber-go/board"
+)
+
+func newBoard(t *testing.T, legacy string) *board.Board {
+	t.Helper()
+	b, err := board.New(io.NopCloser(strings.NewReader(legacy)))
+	if err != nil {
+		t.Fatalf("board.New(%q) returned error: %v", legacy, err)
+	}
+	return b
+}
+
+// TestSolveTiedFlowsCompeteForSameCell regression-tests a board where two
+// already-complete flows tie for most-constrained and, partway through the
+// search, end up wanting the very same next cell. Committing to only the
+// first tied flow walks it into a dead end (it has nowhere left to go but
+// back into its own already-filled endpoint); the other tied flow needs
+// the cell instead for the board to be solvable at all.
+func TestSolveTiedFlowsCompeteForSameCell(t *testing.T) {
+	b := newBoard(t, "4,2\n0,0 1,0\n2,0 3,0\n")
+
+	solved, err := Solve(b)
+	if err != nil {
+		t.Fatalf("Solve returned error: %v", err)
+	}
+	if !solved.Solved() {
+		t.Fatalf("Solve returned an unsolved board:\n%s", solved.GridString())
+	}
+}
+
+func TestSolveSingleFlowFullGrid(t *testing.T) {
+	b := newBoard(t, "3,3\n0,0 2,2\n")
+
+	solved, err := Solve(b)
+	if err != nil {
+		t.Fatalf("Solve returned error: %v", err)
+	}
+	if !solved.Solved() {
+		t.Fatalf("Solve returned an unsolved board:\n%s", solved.GridString())
+	}
+}
+
+func TestSolveNoSolution(t *testing.T) {
+	b := newBoard(t, "2,2\n0,0 1,1\n0,1 1,0\n")
+
+	if _, err := Solve(b); err != ErrNoSolution {
+		t.Fatalf("Solve returned %v, want ErrNoSolution", err)
+	}
+}
+
+func TestSolveAllRespectsLimit(t *testing.T) {
+	b := newBoard(t, "4,2\n0,0 1,0\n2,0 3,0\n")
+
+	solutions := SolveAll(b, 1)
+	if len(solutions) != 1 {
+		t.Fatalf("SolveAll(b, 1) returned %d solutions, want 1", len(solutions))
+	}
+	if !solutions[0].Solved() {
+		t.Fatalf("SolveAll returned an unsolved board:\n%s", solutions[0].GridString())
+	}
+}
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"errors"
+	"os"
+
+	"github.com/etticat/go-plumber-go/board"
+	termbox "github.com/nsf/termbox-go"
+)
+
+// menuOption is one selectable line on the startup menu.
+type menuOption struct {
+	label string
+	run   func() (*board.Board, error)
+	watch bool // launch the App in ModeWatch instead of ModePlay
+}
+
+// RunMenu shows a simple up/down/enter menu letting the player load a
+// puzzle file, then launches an App for the chosen board. This mirrors
+// the menu-driven entry point used for AoC 2016 day 22.
+//
+// Note: the original spec for this package also asked for a "choose board
+// size" entry that handed the player a blank NxN board. That's removed
+// here rather than fixed, because App's play mode only ever extends flows
+// that already have endpoints - board.Board has no endpoint-authoring API
+// - so a blank board couldn't accept a single keypress. Flagging this as
+// a scope cut, not a deferral: shipping it for real needs an endpoint
+// editor added to board.Board first, which is out of scope for this
+// package. Needs sign-off from whoever owns the backlog before this is
+// considered done rather than open.
+func RunMenu(puzzleFiles []string) error {
+	var options []menuOption
+	if len(puzzleFiles) == 0 {
+		return errors.New("tui: RunMenu needs at least one puzzle file")
+	}
+	for _, path := range puzzleFiles {
+		path := path
+		loadPath := func() (*board.Board, error) {
+			f, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			return board.New(f)
+		}
+		options = append(options, menuOption{label: "Load " + path, run: loadPath})
+		options = append(options, menuOption{label: "Watch solver on " + path, run: loadPath, watch: true})
+	}
+
+	if err := termbox.Init(); err != nil {
+		return err
+	}
+
+	selected := 0
+	for {
+		drawMenu(options, selected)
+		termbox.Flush()
+
+		ev := termbox.PollEvent()
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		switch ev.Key {
+		case termbox.KeyArrowUp:
+			if selected > 0 {
+				selected--
+			}
+		case termbox.KeyArrowDown:
+			if selected < len(options)-1 {
+				selected++
+			}
+		case termbox.KeyEnter:
+			termbox.Close()
+			opt := options[selected]
+			b, err := opt.run()
+			if err != nil {
+				return err
+			}
+			app := New(b)
+			if opt.watch {
+				app.Mode = ModeWatch
+			}
+			return app.Run()
+		case termbox.KeyEsc:
+			termbox.Close()
+			return nil
+		}
+	}
+}
+
+func drawMenu(options []menuOption, selected int) {
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	for i, opt := range options {
+		fg := termbox.ColorDefault
+		prefix := "  "
+		if i == selected {
+			fg = termbox.ColorBlack | termbox.AttrBold
+			prefix = "> "
+		}
+		drawString(0, i, prefix+opt.label, fg, termbox.ColorDefault)
+	}
+}
+
+func drawString(x, y int, s string, fg, bg termbox.Attribute) {
+	for i, r := range s {
+		termbox.SetCell(x+i, y, r, fg, bg)
+	}
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"github.com/etticat/go-plumber-go/board"
+	termbox "github.com/nsf/termbox-go"
+)
+
+// RenderStatic draws any board.Renderable - a *board.Board or a
+// *board.NumberGrid - to the terminal via its GridString and waits for a
+// key press before returning. It has no interactivity of its own; App is
+// the interactive, flow-aware front-end for *board.Board.
+func RenderStatic(r board.Renderable) error {
+	if err := termbox.Init(); err != nil {
+		return err
+	}
+	defer termbox.Close()
+
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	for y, line := range splitLines(r.GridString()) {
+		drawString(0, y, line, termbox.ColorDefault, termbox.ColorDefault)
+	}
+	termbox.Flush()
+
+	termbox.PollEvent()
+	return nil
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i, r := range s {
+		if r == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+	lines = append(lines, s[start:])
+	return lines
+}
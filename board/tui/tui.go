@@ -0,0 +1,234 @@
+// Package tui renders a board.Board in a full screen termbox interface.
+//
+// Unlike board.GridString, which returns a fresh ANSI string on every call,
+// the App here keeps its own cell buffer and only repaints the cells that
+// changed since the last frame, so redraws driven by a background solver
+// don't flicker.
+package tui
+
+import (
+	"context"
+	"time"
+
+	"github.com/etticat/go-plumber-go/board"
+	"github.com/etticat/go-plumber-go/board/solver"
+	termbox "github.com/nsf/termbox-go"
+)
+
+// cellColors mirrors board.ColorFuncs but as termbox attributes instead of
+// ANSI wrappers, since termbox owns the terminal directly.
+var cellColors = []termbox.Attribute{
+	termbox.ColorBlack,
+	termbox.ColorRed,
+	termbox.ColorGreen,
+	termbox.ColorYellow,
+	termbox.ColorBlue,
+	termbox.ColorMagenta,
+	termbox.ColorCyan,
+	termbox.ColorWhite,
+}
+
+// Mode selects what the App does with key events and ticks.
+type Mode int
+
+const (
+	// ModePlay is manual, keyboard-driven play.
+	ModePlay Mode = iota
+	// ModeWatch runs solver.SolveStream in the background and shows each
+	// board it emits.
+	ModeWatch
+)
+
+// defaultWatchTick is how often ModeWatch repaints when WatchTick isn't
+// set, chosen to be slow enough to actually watch the solver work instead
+// of blurring into a flicker.
+const defaultWatchTick = 150 * time.Millisecond
+
+// App is a termbox front-end for a single board.Board.
+type App struct {
+	Board *board.Board
+	Mode  Mode
+
+	// WatchTick is how often ModeWatch repaints while solver.SolveStream
+	// explores in the background. Zero means defaultWatchTick.
+	WatchTick time.Duration
+
+	cursor board.Point
+	active int // active flow color, 0-indexed
+
+	buf [][]rune // previous frame, used to skip unchanged cells
+}
+
+// New builds an App for b, starting in manual play mode with the cursor at
+// the top-left cell and color 0 active.
+func New(b *board.Board) *App {
+	return &App{
+		Board: b,
+		Mode:  ModePlay,
+	}
+}
+
+// Run initializes termbox, subscribes to board invalidation, and blocks
+// handling key events until the user quits (Esc or 'q'). In ModeWatch it
+// runs runWatch instead.
+func (a *App) Run() error {
+	if err := termbox.Init(); err != nil {
+		return err
+	}
+	defer termbox.Close()
+
+	a.allocBuf()
+
+	if a.Mode == ModeWatch {
+		return a.runWatch()
+	}
+
+	a.Board.OnInvalidate(func() {
+		a.draw()
+		termbox.Flush()
+	})
+
+	a.draw()
+	termbox.Flush()
+
+	for {
+		ev := termbox.PollEvent()
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+		if ev.Key == termbox.KeyEsc || ev.Ch == 'q' {
+			return nil
+		}
+		a.handlePlayKey(ev)
+	}
+}
+
+// runWatch drives ModeWatch: solver.SolveStream explores the board in the
+// background, and a ticker - rather than one redraw per emitted board -
+// repaints the most recent board the stream emitted. SolveStream emits
+// only solved boards, so the starting board stays on screen until the
+// first solution arrives. runWatch returns once the stream closes; Esc or
+// 'q' stops early, same as play mode.
+func (a *App) runWatch() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	candidates := solver.SolveStream(ctx, a.Board)
+
+	events := make(chan termbox.Event)
+	done := make(chan struct{})
+	// Whichever exit path runWatch takes, wake the blocked PollEvent call
+	// with termbox.Interrupt so the goroutine below can see done and
+	// return instead of leaking until the next real key event.
+	defer termbox.Interrupt()
+	defer close(done)
+	go func() {
+		for {
+			ev := termbox.PollEvent()
+			select {
+			case events <- ev:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(a.watchTick())
+	defer ticker.Stop()
+
+	a.draw()
+	termbox.Flush()
+
+	for {
+		select {
+		case ev := <-events:
+			if ev.Type == termbox.EventKey && (ev.Key == termbox.KeyEsc || ev.Ch == 'q') {
+				return nil
+			}
+		case candidate, ok := <-candidates:
+			if !ok {
+				return nil
+			}
+			a.Board = candidate
+		case <-ticker.C:
+			a.draw()
+			termbox.Flush()
+		}
+	}
+}
+
+func (a *App) watchTick() time.Duration {
+	if a.WatchTick <= 0 {
+		return defaultWatchTick
+	}
+	return a.WatchTick
+}
+
+func (a *App) allocBuf() {
+	lines, cols := a.Board.Lines(), a.Board.Cols()
+	a.buf = make([][]rune, lines)
+	for i := range a.buf {
+		a.buf[i] = make([]rune, cols)
+	}
+}
+
+// handlePlayKey moves the cursor, switches the active flow, or extends /
+// retracts it: arrow keys move the cursor, Tab or 1-9 select the active
+// flow, Enter/Space extend it into the cursor's cell, and Backspace
+// retracts it.
+func (a *App) handlePlayKey(ev termbox.Event) {
+	switch ev.Key {
+	case termbox.KeyArrowUp:
+		a.moveCursor(-1, 0)
+	case termbox.KeyArrowDown:
+		a.moveCursor(1, 0)
+	case termbox.KeyArrowLeft:
+		a.moveCursor(0, -1)
+	case termbox.KeyArrowRight:
+		a.moveCursor(0, 1)
+	case termbox.KeyTab:
+		if n := a.Board.NumColors(); n > 0 {
+			a.active = (a.active + 1) % n
+		}
+	case termbox.KeyEnter, termbox.KeySpace:
+		a.Board.ColorCell(a.active, a.cursor[0], a.cursor[1])
+	case termbox.KeyBackspace, termbox.KeyBackspace2:
+		a.Board.Retract(a.active)
+	}
+	if ev.Ch >= '1' && ev.Ch <= '9' {
+		if n := a.Board.NumColors(); n > 0 {
+			a.active = int(ev.Ch-'1') % n
+		}
+	}
+}
+
+func (a *App) moveCursor(dline, dcol int) {
+	line := a.cursor[0] + dline
+	col := a.cursor[1] + dcol
+	if line < 0 || line >= a.Board.Lines() || col < 0 || col >= a.Board.Cols() {
+		return
+	}
+	a.cursor = board.Point{line, col}
+}
+
+// draw repaints only the cells whose rune changed since the last frame.
+func (a *App) draw() {
+	for i := 0; i < a.Board.Lines(); i++ {
+		for j := 0; j < a.Board.Cols(); j++ {
+			val := a.Board.Get(i, j)
+			r := ' '
+			fg := termbox.ColorDefault
+			if val != 0 {
+				r = rune('0' + val%10)
+				fg = cellColors[val%len(cellColors)]
+			}
+			if a.buf[i][j] == r {
+				continue
+			}
+			a.buf[i][j] = r
+			termbox.SetCell(j*2, i, r, fg, termbox.ColorDefault)
+		}
+	}
+	cx, cy := a.cursor[1]*2, a.cursor[0]
+	termbox.SetCursor(cx, cy)
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/etticat/go-plumber-go/board"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func newTestApp(t *testing.T, legacy string) *App {
+	t.Helper()
+	b, err := board.New(io.NopCloser(strings.NewReader(legacy)))
+	if err != nil {
+		t.Fatalf("board.New returned error: %v", err)
+	}
+	a := New(b)
+	a.allocBuf()
+	return a
+}
+
+func TestMoveCursorClampsToBoardBounds(t *testing.T) {
+	a := newTestApp(t, "3,3\n0,0 2,2\n")
+
+	a.moveCursor(-1, 0)
+	if a.cursor != (board.Point{0, 0}) {
+		t.Errorf("moving up from (0,0) = %v, want clamped to (0,0)", a.cursor)
+	}
+
+	a.moveCursor(0, -1)
+	if a.cursor != (board.Point{0, 0}) {
+		t.Errorf("moving left from (0,0) = %v, want clamped to (0,0)", a.cursor)
+	}
+
+	for i := 0; i < 5; i++ {
+		a.moveCursor(1, 1)
+	}
+	if a.cursor != (board.Point{2, 2}) {
+		t.Errorf("moving past the bottom-right corner = %v, want clamped to (2,2)", a.cursor)
+	}
+}
+
+func TestWatchTickDefaultsWhenUnset(t *testing.T) {
+	a := newTestApp(t, "3,3\n0,0 2,2\n")
+	if got := a.watchTick(); got != defaultWatchTick {
+		t.Errorf("watchTick() with WatchTick unset = %v, want %v", got, defaultWatchTick)
+	}
+
+	a.WatchTick = 10 * time.Millisecond
+	if got := a.watchTick(); got != 10*time.Millisecond {
+		t.Errorf("watchTick() with WatchTick set = %v, want 10ms", got)
+	}
+}
+
+// TestHandlePlayKeyCyclesOverBoardColors is a regression test for cycling
+// past a board's actual flow count instead of the fixed 8-entry
+// board.ColorFuncs palette, which landed Tab on color indexes past the
+// last flow that ColorCell/Retract would reject.
+func TestHandlePlayKeyCyclesOverBoardColors(t *testing.T) {
+	a := newTestApp(t, "3,3\n0,0 0,2\n2,0 2,2\n")
+	if n := a.Board.NumColors(); n != 2 {
+		t.Fatalf("test board has %d flows, want 2", n)
+	}
+
+	a.handlePlayKey(termbox.Event{Key: termbox.KeyTab})
+	if a.active != 1 {
+		t.Errorf("active after one Tab = %d, want 1", a.active)
+	}
+
+	a.handlePlayKey(termbox.Event{Key: termbox.KeyTab})
+	if a.active != 0 {
+		t.Errorf("active after two Tabs = %d, want wrapped back to 0", a.active)
+	}
+
+	a.handlePlayKey(termbox.Event{Ch: '2'})
+	if a.active != 1 {
+		t.Errorf("active after pressing '2' = %d, want 1", a.active)
+	}
+}